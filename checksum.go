@@ -0,0 +1,67 @@
+package sleekid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+)
+
+// Checksummer computes a checksum for an id's payload.
+//
+// Sum must append the checksum to dst and return the extended slice, in the
+// same vein as hash.Hash.Sum. The returned checksum must be exactly as long
+// as the generator's ChecksumLength.
+type Checksummer interface {
+	Sum(dst, src []byte) []byte
+}
+
+// HMACChecksummer is a Checksummer backed by HMAC-SHA256, truncated and
+// base62-encoded to Length characters. Unlike the legacy token-based
+// checksum, recovering Key from a handful of valid ids is computationally
+// infeasible.
+type HMACChecksummer struct {
+	// Key is the HMAC key. It must not be empty.
+	Key []byte
+
+	// Length is the number of base62 characters the checksum is encoded to.
+	Length int
+
+	// Alphabet is the base62 alphabet used to encode the checksum. Defaults
+	// to sleekid's alphabetical-order alphabet when empty.
+	Alphabet string
+}
+
+// NewHMACChecksummer returns an HMACChecksummer keyed by key, producing
+// checksums of the given length encoded with alphabet.
+func NewHMACChecksummer(key []byte, length int, alphabet string) *HMACChecksummer {
+	return &HMACChecksummer{Key: key, Length: length, Alphabet: alphabet}
+}
+
+func (c *HMACChecksummer) Sum(dst, src []byte) []byte {
+	alphabet := c.Alphabet
+	if alphabet == "" {
+		alphabet = defaultAlphabet
+	}
+
+	mac := hmac.New(sha256.New, c.Key)
+	mac.Write(src)
+	sum := mac.Sum(nil)
+
+	result := make([]byte, c.Length)
+	for i := 0; i < c.Length; i++ {
+		result[i] = alphabet[sum[i%len(sum)]%62]
+	}
+	return append(dst, result...)
+}
+
+// legacyChecksummer adapts the original token-keyed checksum to the
+// Checksummer interface, so that GeneratorInit.ChecksumToken keeps working
+// unchanged when no Checksummer, Key or KeyRotation is set.
+type legacyChecksummer struct {
+	token    uint64
+	length   int
+	alphabet string
+}
+
+func (c *legacyChecksummer) Sum(dst, src []byte) []byte {
+	return append(dst, generateChecksum(src, c.length, c.token, c.alphabet)...)
+}
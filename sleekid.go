@@ -3,6 +3,9 @@ package sleekid
 import (
 	"bytes"
 	"fmt"
+	"hash/crc32"
+	"os"
+	"sync/atomic"
 	"time"
 
 	"crypto/rand"
@@ -28,6 +31,23 @@ func New(prefix string, options ...*GenerateOption) (SleekId, error) {
 	return generator.New(prefix, options...)
 }
 
+// NewInto generates a new id with the given prefix, writing it into dst
+// (reusing its backing array when there's room) instead of allocating.
+//
+//	buf := make([]byte, 0, 32)
+//	id, err := sleekid.NewInto(buf, "usr")
+func NewInto(dst []byte, prefix string, options ...*GenerateOption) (SleekId, error) {
+	return generator.NewInto(dst, prefix, options...)
+}
+
+// NewBatch generates n ids with the given prefix in one call, amortizing the
+// crypto/rand read and the id-buffer allocations across the batch.
+//
+//	ids, err := sleekid.NewBatch("usr", 1000)
+func NewBatch(prefix string, n int, options ...*GenerateOption) ([]SleekId, error) {
+	return generator.NewBatch(prefix, n, options...)
+}
+
 // Prefix returns the prefix of the given id.
 //
 //	sleekid.Prefix(id)
@@ -66,6 +86,13 @@ func (id SleekId) String() string {
 type GenerateOption struct {
 	// RandomDigitsLength is the length of the random part of the id.
 	RandomDigitsLength int
+
+	// Monotonic forces xid-style monotonic ordering on for this call,
+	// regardless of the generator's GeneratorInit.Monotonic default.
+	//
+	// See GeneratorInit.Monotonic for what this changes about the layout
+	// of the random section.
+	Monotonic bool
 }
 
 type TimestampOrder int
@@ -83,9 +110,32 @@ type GeneratorInit struct {
 
 	// checksumToken is used to verify the id. Don't expose it to the public.
 	//
+	// Deprecated: the token-keyed checksum is forgeable by anyone who has
+	// seen a handful of valid ids. Prefer Key or Checksummer. ChecksumToken
+	// is only used when none of Checksummer, Key and KeyRotation are set.
+	//
 	// Default is 4567890. Change it on your production environment.
 	ChecksumToken uint64
 
+	// Checksummer computes the checksum part of the id. When set, it takes
+	// precedence over Key and ChecksumToken, but is itself overridden by
+	// KeyRotation if that's set too.
+	Checksummer Checksummer
+
+	// Key, when set, builds an HMACChecksummer keyed by it. It takes
+	// precedence over ChecksumToken but is overridden by Checksummer and
+	// KeyRotation.
+	Key []byte
+
+	// KeyRotation is an ordered list of HMAC keys for zero-downtime key
+	// rotation: New signs with the first key, while Validate accepts ids
+	// signed by any key in the list. Set this instead of Key when rotating.
+	// KeyRotation takes precedence over Checksummer and Key when more than
+	// one of them is set.
+	//
+	// Precedence overall: KeyRotation > Checksummer > Key > ChecksumToken.
+	KeyRotation [][]byte
+
 	// ChecksumLength is the length of the checksum part of the id.
 	// This will increase the precisition of the false detection rate.
 	//
@@ -111,6 +161,21 @@ type GeneratorInit struct {
 	//
 	// Default is Alphabetical order.
 	TimestampOrder TimestampOrder
+
+	// Monotonic switches the random section to an xid-style layout: a 3-byte
+	// machine id (hashed from os.Hostname, falling back to crypto/rand), a
+	// 2-byte process id (os.Getpid), and a per-generator counter that is
+	// seeded from crypto/rand and incremented atomically for every id,
+	// filling whatever room is left. The counter comes last, so ids
+	// generated by the same generator within the same second sort
+	// monotonically, while the machine+pid bytes keep ids from different
+	// processes from colliding without needing a crypto/rand draw per call.
+	//
+	// RandomDigitsLength (the effective one, after GenerateOption overrides)
+	// must be at least 9 when Monotonic is enabled.
+	//
+	// Default is false.
+	Monotonic bool
 }
 
 // WithRandomBytes is a helper function to set the RandomDigitsLength option.
@@ -118,6 +183,12 @@ func WithRandomBytes(length int) *GenerateOption {
 	return &GenerateOption{RandomDigitsLength: length}
 }
 
+// WithMonotonic is a helper function to force-enable monotonic ordering for
+// a single call, regardless of the generator's default.
+func WithMonotonic() *GenerateOption {
+	return &GenerateOption{Monotonic: true}
+}
+
 type Generator interface {
 	// New generates a new id with the given prefix.
 	//
@@ -126,6 +197,21 @@ type Generator interface {
 	//	id, err := gen.New("usr", WithRandomBytes(16))
 	New(prefix string, options ...*GenerateOption) (SleekId, error)
 
+	// NewInto generates a new id with the given prefix, writing it into dst
+	// (reusing its backing array when there's room) instead of allocating.
+	//
+	//	gen := NewGenerator(GeneratorInit{...})
+	//	buf := make([]byte, 0, 32)
+	//	id, err := gen.NewInto(buf, "usr")
+	NewInto(dst []byte, prefix string, options ...*GenerateOption) (SleekId, error)
+
+	// NewBatch generates n ids with the given prefix in one call, amortizing
+	// the crypto/rand read and the id-buffer allocations across the batch.
+	//
+	//	gen := NewGenerator(GeneratorInit{...})
+	//	ids, err := gen.NewBatch("usr", 1000)
+	NewBatch(prefix string, n int, options ...*GenerateOption) ([]SleekId, error)
+
 	// Prefix returns the prefix of the given id.
 	//
 	//	gen := NewGenerator(GeneratorInit{...})
@@ -153,16 +239,54 @@ type Generator interface {
 
 type sleekIdGen struct {
 	delimiter          byte
-	checksumToken      uint64
 	checksumLength     int
 	randomDigitsLength int
 	timestampLength    int
 	alphabet           string
 	alphabetBytes      []byte
+
+	// checksummer signs new ids; validators are the checksummers accepted
+	// by Validate, checksummer always being validators[0].
+	checksummer Checksummer
+	validators  []Checksummer
+
+	monotonic bool
+	machineId [monotonicMachineIdLength]byte
+	pid       [monotonicPidLength]byte
+	counter   uint32
 }
 
 const baseUnixEpoch = 1704067200 // 2024-01-01 00:00:00 UTC
 
+const (
+	monotonicMachineIdLength = 3
+	monotonicPidLength       = 2
+	monotonicFixedLength     = monotonicMachineIdLength + monotonicPidLength
+
+	// monotonicMinCounterLength is the fewest base62 digits the counter is
+	// allowed to shrink to; below that, same-second collisions within a
+	// process stop being practically impossible.
+	monotonicMinCounterLength = 4
+
+	// monotonicReservedLength is the minimum RandomDigitsLength monotonic
+	// mode needs: the machine id and pid, plus a usable counter. Any extra
+	// room beyond this all goes to the counter, not to plain randomness.
+	monotonicReservedLength = monotonicFixedLength + monotonicMinCounterLength
+)
+
+// monotonicAlphabet is the base62 alphabet the monotonic suffix is encoded
+// with, independent of the generator's own alphabet. Monotonic ordering
+// relies on bytes.Compare, which compares ASCII values ('0'-'9' < 'A'-'Z' <
+// 'a'-'z'), so the alphabet used for it must list its digits in that same
+// order — the generator's default digits-lower-upper alphabet doesn't, and
+// using it here would flip the counter's sort order every time a digit
+// carries past 'z' into 'A'.
+const monotonicAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// defaultAlphabet is the alphabetical-order base62 alphabet, used whenever a
+// Checksummer isn't given an explicit one.
+const defaultAlphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
 func NewGenerator(init GeneratorInit) Generator {
 	delimiter := byte('_')
 	if init.Delimiter != 0 {
@@ -187,48 +311,265 @@ func NewGenerator(init GeneratorInit) Generator {
 		panic("TimestampLength must be 4 <= TimestampLength <= 6")
 	}
 	// Alphabetical order: 0-9 < a-z < A-Z
-	alphabet := "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	alphabet := defaultAlphabet
 	if init.TimestampOrder == TimestampOrderASCII {
 		// ASCII order: 0-9 < A-Z < a-z
 		alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
 	}
+	// The machine id, pid and counter are cheap to compute and are derived
+	// unconditionally, so that GenerateOption.Monotonic can turn monotonic
+	// ordering on for a single call even on a generator whose
+	// GeneratorInit.Monotonic default is false.
+	counterLength := randomDigitsLength - monotonicFixedLength
+	if counterLength < 0 {
+		counterLength = 0
+	}
+
+	checksummer, validators := newChecksummers(init, checksumLength, checksumToken, alphabet)
+
 	return &sleekIdGen{
 		delimiter:          delimiter,
-		checksumToken:      checksumToken,
 		checksumLength:     checksumLength,
 		randomDigitsLength: randomDigitsLength,
 		timestampLength:    timestampLength,
 		alphabet:           alphabet,
 		alphabetBytes:      []byte(alphabet),
+		checksummer:        checksummer,
+		validators:         validators,
+		monotonic:          init.Monotonic,
+		machineId:          readMachineId(),
+		pid:                pidBytes(),
+		counter:            seedCounter(counterLength),
 	}
 }
 
-func (o *sleekIdGen) New(prefix string, options ...*GenerateOption) (SleekId, error) {
-	randomDigitsLength := o.randomDigitsLength
-	if len(options) > 0 {
-		randomDigitsLength = options[0].RandomDigitsLength
+// newChecksummers resolves the checksummer New signs with and the full set
+// of checksummers Validate accepts, honouring the precedence documented on
+// GeneratorInit: KeyRotation > Checksummer > Key > ChecksumToken.
+func newChecksummers(init GeneratorInit, checksumLength int, checksumToken uint64, alphabet string) (checksummer Checksummer, validators []Checksummer) {
+	switch {
+	case len(init.KeyRotation) > 0:
+		validators = make([]Checksummer, len(init.KeyRotation))
+		for i, key := range init.KeyRotation {
+			validators[i] = NewHMACChecksummer(key, checksumLength, alphabet)
+		}
+		return validators[0], validators
+	case init.Checksummer != nil:
+		return init.Checksummer, []Checksummer{init.Checksummer}
+	case len(init.Key) > 0:
+		checksummer = NewHMACChecksummer(init.Key, checksumLength, alphabet)
+		return checksummer, []Checksummer{checksummer}
+	default:
+		checksummer = &legacyChecksummer{token: checksumToken, length: checksumLength, alphabet: alphabet}
+		return checksummer, []Checksummer{checksummer}
 	}
-	timestamp := timestampToSortableString(time.Now(), o.timestampLength, o.alphabet)
-	randomBytes := make([]byte, randomDigitsLength)
-	_, err := rand.Read(randomBytes)
+}
+
+// readMachineId derives a 3-byte machine id from the hostname, falling back
+// to crypto/rand if the hostname can't be resolved.
+func readMachineId() [monotonicMachineIdLength]byte {
+	var id [monotonicMachineIdLength]byte
+	hostname, err := os.Hostname()
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate random bytes: %w", err)
+		_, _ = rand.Read(id[:])
+		return id
 	}
-	for i, b := range randomBytes {
-		randomBytes[i] = o.alphabet[b%62]
+	sum := crc32.ChecksumIEEE([]byte(hostname))
+	id[0] = byte(sum >> 16)
+	id[1] = byte(sum >> 8)
+	id[2] = byte(sum)
+	return id
+}
+
+// pidBytes returns the low 2 bytes of the current process id.
+func pidBytes() [monotonicPidLength]byte {
+	pid := os.Getpid()
+	return [monotonicPidLength]byte{byte(pid >> 8), byte(pid)}
+}
+
+func (o *sleekIdGen) New(prefix string, options ...*GenerateOption) (SleekId, error) {
+	randomDigitsLength, monotonic := o.callOptions(options)
+	timestamp := timestampToSortableString(time.Now(), o.timestampLength, o.alphabet)
+	randomBytes, err := o.randomSection(randomDigitsLength, monotonic)
+	if err != nil {
+		return nil, err
 	}
 
 	// prefix + "_" + random bits + checksum bits
-	id := make([]byte, 0, len(prefix)+1+len(timestamp)+randomDigitsLength+o.checksumLength)
+	id := make([]byte, 0, len(prefix)+1+len(timestamp)+len(randomBytes)+o.checksumLength)
 	id = append(id, prefix...)
 	id = append(id, o.delimiter)
 	id = append(id, timestamp...)
 	id = append(id, randomBytes...)
-	checksum := generateChecksum(id, o.checksumLength, o.checksumToken, o.alphabet)
-	id = append(id, checksum...)
+	id = o.checksummer.Sum(id, id)
 	return SleekId(id), nil
 }
 
+func (o *sleekIdGen) NewInto(dst []byte, prefix string, options ...*GenerateOption) (SleekId, error) {
+	randomDigitsLength, monotonic := o.callOptions(options)
+	timestamp := timestampToSortableString(time.Now(), o.timestampLength, o.alphabet)
+	randomBytes, err := o.randomSection(randomDigitsLength, monotonic)
+	if err != nil {
+		return nil, err
+	}
+
+	size := len(prefix) + 1 + len(timestamp) + len(randomBytes) + o.checksumLength
+	if cap(dst) < size {
+		dst = make([]byte, 0, size)
+	} else {
+		dst = dst[:0]
+	}
+	dst = append(dst, prefix...)
+	dst = append(dst, o.delimiter)
+	dst = append(dst, timestamp...)
+	dst = append(dst, randomBytes...)
+	dst = o.checksummer.Sum(dst, dst)
+	return SleekId(dst), nil
+}
+
+func (o *sleekIdGen) NewBatch(prefix string, n int, options ...*GenerateOption) ([]SleekId, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	randomDigitsLength, monotonic := o.callOptions(options)
+
+	var randomPool []byte
+	var counterBase uint32
+	var counterLength int
+	if monotonic {
+		if randomDigitsLength < monotonicReservedLength {
+			return nil, fmt.Errorf("sleekid: RandomDigitsLength must be at least %d when Monotonic is enabled", monotonicReservedLength)
+		}
+		counterLength = randomDigitsLength - monotonicFixedLength
+		// One atomic reservation for the whole batch, instead of one per id.
+		counterBase = atomic.AddUint32(&o.counter, uint32(n)) - uint32(n)
+	} else {
+		// One rand.Read call for all the random bytes the whole batch needs.
+		randomPool = make([]byte, n*randomDigitsLength)
+		if _, err := rand.Read(randomPool); err != nil {
+			return nil, fmt.Errorf("failed to generate random bytes: %w", err)
+		}
+	}
+
+	timestamp := timestampToSortableString(time.Now(), o.timestampLength, o.alphabet)
+	idSize := len(prefix) + 1 + o.timestampLength + randomDigitsLength + o.checksumLength
+
+	// A single scratch buffer sized for the whole batch, sliced per id,
+	// instead of one allocation per id.
+	scratch := make([]byte, 0, idSize*n)
+	ids := make([]SleekId, n)
+	for i := 0; i < n; i++ {
+		start := len(scratch)
+		scratch = append(scratch, prefix...)
+		scratch = append(scratch, o.delimiter)
+		scratch = append(scratch, timestamp...)
+		if monotonic {
+			scratch = append(scratch, o.monotonicSuffix(counterBase+uint32(i), counterLength)...)
+		} else {
+			for _, b := range randomPool[i*randomDigitsLength : (i+1)*randomDigitsLength] {
+				scratch = append(scratch, o.alphabet[b%62])
+			}
+		}
+		scratch = o.checksummer.Sum(scratch, scratch[start:])
+		ids[i] = SleekId(scratch[start:len(scratch)])
+	}
+	return ids, nil
+}
+
+// callOptions resolves the effective RandomDigitsLength and Monotonic
+// setting for a single call, applying any GenerateOption override on top of
+// the generator's defaults.
+func (o *sleekIdGen) callOptions(options []*GenerateOption) (randomDigitsLength int, monotonic bool) {
+	randomDigitsLength = o.randomDigitsLength
+	monotonic = o.monotonic
+	for _, option := range options {
+		if option.RandomDigitsLength > 0 {
+			randomDigitsLength = option.RandomDigitsLength
+		}
+		if option.Monotonic {
+			monotonic = true
+		}
+	}
+	return randomDigitsLength, monotonic
+}
+
+// randomSection builds the random part of an id. In monotonic mode it's
+// entirely structured (machine id, pid, counter) and needs no crypto/rand
+// draw of its own; otherwise it's plain crypto/rand.
+func (o *sleekIdGen) randomSection(randomDigitsLength int, monotonic bool) ([]byte, error) {
+	if !monotonic {
+		randomBytes := make([]byte, randomDigitsLength)
+		if _, err := rand.Read(randomBytes); err != nil {
+			return nil, fmt.Errorf("failed to generate random bytes: %w", err)
+		}
+		for i, b := range randomBytes {
+			randomBytes[i] = o.alphabet[b%62]
+		}
+		return randomBytes, nil
+	}
+
+	if randomDigitsLength < monotonicReservedLength {
+		return nil, fmt.Errorf("sleekid: RandomDigitsLength must be at least %d when Monotonic is enabled", monotonicReservedLength)
+	}
+
+	counter := atomic.AddUint32(&o.counter, 1) - 1
+	return o.monotonicSuffix(counter, randomDigitsLength-monotonicFixedLength), nil
+}
+
+// monotonicSuffix encodes the machine id, pid and counter into
+// monotonicFixedLength+counterLength base62 characters, counter last so
+// that ids from the same generator sort monotonically within the same
+// second. It always uses monotonicAlphabet, not o.alphabet, so the
+// guarantee holds regardless of the generator's TimestampOrder.
+func (o *sleekIdGen) monotonicSuffix(counter uint32, counterLength int) []byte {
+	suffix := make([]byte, 0, monotonicFixedLength+counterLength)
+	for _, b := range o.machineId {
+		suffix = append(suffix, monotonicAlphabet[b%62])
+	}
+	for _, b := range o.pid {
+		suffix = append(suffix, monotonicAlphabet[b%62])
+	}
+	suffix = append(suffix, encodeFixedWidthBase62(int64(counter), counterLength, monotonicAlphabet)...)
+	return suffix
+}
+
+// maxCounterValue is the largest value the counter (a uint32) can hold.
+const maxCounterValue = 1<<32 - 1
+
+// counterSeedByteLength returns the fewest bytes (up to 4, since the counter
+// is a uint32) needed to cover the 62^counterLength values that
+// counterLength base62 digits can represent.
+func counterSeedByteLength(counterLength int) int {
+	capacity := uint64(1)
+	for i := 0; i < counterLength; i++ {
+		capacity *= 62
+		if capacity > maxCounterValue {
+			return 4
+		}
+	}
+	for n := 1; n < 4; n++ {
+		if uint64(1)<<(8*n) >= capacity {
+			return n
+		}
+	}
+	return 4
+}
+
+// seedCounter draws a random starting value for the monotonic counter. It
+// reads only as many random bytes as counterLength's encoded width can
+// actually represent, instead of always drawing a full uint32:
+// encodeFixedWidthBase62 truncates to the low-order digits anyway, so a
+// wider draw would just waste entropy.
+func seedCounter(counterLength int) uint32 {
+	seed := make([]byte, counterSeedByteLength(counterLength))
+	_, _ = rand.Read(seed)
+	var value uint32
+	for _, b := range seed {
+		value = value<<8 | uint32(b)
+	}
+	return value
+}
+
 func (o *sleekIdGen) Prefix(id SleekId) string {
 	delimiterPos := -1
 	for i, b := range id {
@@ -254,7 +595,12 @@ func (o *sleekIdGen) Validate(id SleekId) bool {
 		return false
 	}
 	idPart, checksum := id[:len(id)-o.checksumLength], id[len(id)-o.checksumLength:]
-	return subtle.ConstantTimeCompare(checksum, generateChecksum(idPart, o.checksumLength, o.checksumToken, o.alphabet)) == 1
+	for _, v := range o.validators {
+		if subtle.ConstantTimeCompare(checksum, v.Sum(nil, idPart)) == 1 {
+			return true
+		}
+	}
+	return false
 }
 
 func (o *sleekIdGen) ValidateWithPrefix(prefix string, id SleekId) bool {
@@ -265,13 +611,20 @@ func (o *sleekIdGen) ValidateWithPrefix(prefix string, id SleekId) bool {
 }
 
 func timestampToSortableString(t time.Time, length int, alphabet string) []byte {
-	timeValue := t.Unix() - baseUnixEpoch
+	return encodeFixedWidthBase62(t.Unix()-baseUnixEpoch, length, alphabet)
+}
 
+// encodeFixedWidthBase62 encodes value as a fixed-width, left-padded base62
+// string, keeping only the low-order length digits of larger values. With an
+// alphabet listed in ASCII order (such as monotonicAlphabet) and values below
+// 62^length, byte-wise comparison of two encodings of the same width matches
+// numeric comparison of the values.
+func encodeFixedWidthBase62(value int64, length int, alphabet string) []byte {
 	result := make([]byte, 0, length)
 
-	for timeValue > 0 {
-		result = append(result, alphabet[int(timeValue%62)])
-		timeValue = timeValue / 62
+	for value > 0 {
+		result = append(result, alphabet[int(value%62)])
+		value = value / 62
 	}
 
 	for len(result) < length {
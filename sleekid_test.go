@@ -1,6 +1,7 @@
 package sleekid
 
 import (
+	"bytes"
 	"regexp"
 	"sync"
 	"testing"
@@ -20,6 +21,30 @@ func BenchmarkNew(b *testing.B) {
 	}
 }
 
+// BenchmarkNew1000 and BenchmarkNewBatch1000 show the allocs/op improvement
+// from amortizing the crypto/rand read and the id-buffer allocations across
+// a batch: NewBatch(1000) does one rand.Read and one backing allocation,
+// instead of 1000 of each.
+func BenchmarkNew1000(b *testing.B) {
+	gen := NewGenerator(GeneratorInit{ChecksumToken: 30, RandomDigitsLength: 15})
+	prefix := "usr"
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 1000; j++ {
+			_, _ = gen.New(prefix)
+		}
+	}
+}
+
+func BenchmarkNewBatch1000(b *testing.B) {
+	gen := NewGenerator(GeneratorInit{ChecksumToken: 30, RandomDigitsLength: 15})
+	prefix := "usr"
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = gen.NewBatch(prefix, 1000)
+	}
+}
+
 func BenchmarkPrefix(b *testing.B) {
 	gen := NewGenerator(GeneratorInit{ChecksumToken: 30, RandomDigitsLength: 15, ChecksumLength: 4, Delimiter: '_'})
 	b.ResetTimer()
@@ -164,6 +189,63 @@ func TestNewConcurrentGeneration(t *testing.T) {
 	wg.Wait()
 }
 
+func TestHMACChecksummer_shouldValidateOwnIds(t *testing.T) {
+	gen := NewGenerator(GeneratorInit{Key: []byte("super-secret-key"), RandomDigitsLength: 15})
+	id, err := gen.New("usr")
+	assert.NilError(t, err)
+	assert.Equal(t, true, gen.Validate(id))
+}
+
+func TestHMACChecksummer_shouldRejectForgedIdUnderGuessedLegacyToken(t *testing.T) {
+	legacy := NewGenerator(GeneratorInit{ChecksumToken: 30, RandomDigitsLength: 15})
+	id, err := legacy.New("usr")
+	assert.NilError(t, err)
+	assert.Equal(t, true, legacy.Validate(id))
+
+	// An attacker who has reconstructed the 64-bit token from a handful of
+	// legacy ids can forge ids for that generator...
+	forger := NewGenerator(GeneratorInit{ChecksumToken: 30, RandomDigitsLength: 15})
+	forgedId, err := forger.New("usr")
+	assert.NilError(t, err)
+	assert.Equal(t, true, legacy.Validate(forgedId))
+
+	// ...but the same attack against an HMAC-keyed generator fails, because
+	// the guessed value isn't the real key.
+	hmacGen := NewGenerator(GeneratorInit{Key: []byte("a-real-key-the-attacker-never-sees"), RandomDigitsLength: 15})
+	hmacId, err := hmacGen.New("usr")
+	assert.NilError(t, err)
+	guesser := NewGenerator(GeneratorInit{Key: []byte("30"), RandomDigitsLength: 15})
+	assert.Equal(t, false, guesser.Validate(hmacId))
+}
+
+func TestKeyRotation_shouldValidateUnderAnyKeyButSignWithFirst(t *testing.T) {
+	oldKey := []byte("old-key")
+	newKey := []byte("new-key")
+
+	rotating := NewGenerator(GeneratorInit{KeyRotation: [][]byte{newKey, oldKey}, RandomDigitsLength: 15})
+	oldGen := NewGenerator(GeneratorInit{Key: oldKey, RandomDigitsLength: 15})
+
+	oldId, err := oldGen.New("usr")
+	assert.NilError(t, err)
+	assert.Equal(t, true, rotating.Validate(oldId), "should accept ids signed by a retired key still in KeyRotation")
+
+	newId, err := rotating.New("usr")
+	assert.NilError(t, err)
+	newOnlyGen := NewGenerator(GeneratorInit{Key: newKey, RandomDigitsLength: 15})
+	assert.Equal(t, true, newOnlyGen.Validate(newId), "New should sign with the first key in KeyRotation")
+}
+
+func TestCustomChecksummer(t *testing.T) {
+	gen := NewGenerator(GeneratorInit{
+		Checksummer:        NewHMACChecksummer([]byte("key"), 4, defaultAlphabet),
+		RandomDigitsLength: 15,
+		ChecksumLength:     4,
+	})
+	id, err := gen.New("usr")
+	assert.NilError(t, err)
+	assert.Equal(t, true, gen.Validate(id))
+}
+
 func TestCustomChecksumLength(t *testing.T) {
 	gen := NewGenerator(GeneratorInit{
 		ChecksumToken:      100,
@@ -192,6 +274,93 @@ func TestCustomTimestampLength(t *testing.T) {
 	assert.Assert(t, regexp.MustCompile(`^[a-z]+_[A-Za-z0-9]+$`).MatchString(string(id)))
 }
 
+func TestNewMonotonic_shouldSortInTightLoop(t *testing.T) {
+	gen := NewGenerator(GeneratorInit{ChecksumToken: 30, RandomDigitsLength: 15, Monotonic: true})
+
+	var prev SleekId
+	for i := 0; i < 1000; i++ {
+		id, err := gen.New("usr")
+		assert.NilError(t, err)
+		if prev != nil {
+			assert.Assert(t, bytes.Compare(prev, id) < 0)
+		}
+		prev = id
+	}
+}
+
+func TestNewMonotonic_shouldRejectTooShortRandomDigitsLength(t *testing.T) {
+	gen := NewGenerator(GeneratorInit{ChecksumToken: 30, RandomDigitsLength: 5, Monotonic: true})
+	_, err := gen.New("usr")
+	assert.Assert(t, err != nil)
+}
+
+func TestNewWithMonotonicOption_shouldSortEvenWhenGeneratorDefaultIsOff(t *testing.T) {
+	gen := NewGenerator(GeneratorInit{ChecksumToken: 30, RandomDigitsLength: 15})
+
+	var prev SleekId
+	for i := 0; i < 1000; i++ {
+		id, err := gen.New("usr", WithMonotonic())
+		assert.NilError(t, err)
+		if prev != nil {
+			assert.Assert(t, bytes.Compare(prev, id) < 0)
+		}
+		prev = id
+	}
+}
+
+func TestNewInto_shouldReuseBuffer(t *testing.T) {
+	gen := NewGenerator(GeneratorInit{ChecksumToken: 30, RandomDigitsLength: 10})
+	buf := make([]byte, 0, 64)
+	bufPtr := &buf[:1][0]
+
+	id, err := gen.NewInto(buf, "usr")
+	assert.NilError(t, err)
+	assert.Equal(t, true, gen.Validate(id))
+	assert.Equal(t, "usr", gen.Prefix(id))
+	assert.Assert(t, bufPtr == &id[:1][0], "NewInto should write into dst's backing array when cap(dst) is sufficient")
+
+	allocsWithCap := testing.AllocsPerRun(100, func() {
+		_, _ = gen.NewInto(buf, "usr")
+	})
+	allocsWithoutCap := testing.AllocsPerRun(100, func() {
+		_, _ = gen.NewInto(nil, "usr")
+	})
+	assert.Assert(t, allocsWithCap < allocsWithoutCap, "NewInto should allocate less when dst already has enough capacity")
+}
+
+func TestNewBatch_shouldGenerateDistinctValidIds(t *testing.T) {
+	gen := NewGenerator(GeneratorInit{ChecksumToken: 30, RandomDigitsLength: 15})
+
+	ids, err := gen.NewBatch("usr", 500)
+	assert.NilError(t, err)
+	assert.Equal(t, 500, len(ids))
+
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		assert.Equal(t, true, gen.Validate(id))
+		assert.Equal(t, "usr", gen.Prefix(id))
+		assert.Equal(t, false, seen[id.String()], "Duplicate ID generated")
+		seen[id.String()] = true
+	}
+}
+
+func TestNewBatch_shouldStayMonotonicWithinTheBatch(t *testing.T) {
+	gen := NewGenerator(GeneratorInit{ChecksumToken: 30, RandomDigitsLength: 15, Monotonic: true})
+
+	ids, err := gen.NewBatch("usr", 500)
+	assert.NilError(t, err)
+	for i := 1; i < len(ids); i++ {
+		assert.Assert(t, bytes.Compare(ids[i-1], ids[i]) < 0)
+	}
+}
+
+func TestNewBatch_shouldReturnEmptyForNonPositiveN(t *testing.T) {
+	gen := NewGenerator(GeneratorInit{ChecksumToken: 30, RandomDigitsLength: 15})
+	ids, err := gen.NewBatch("usr", 0)
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(ids))
+}
+
 func TestTimestampPadding(t *testing.T) {
 	alphabet := "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
 	str := timestampToSortableString(time.Unix(baseUnixEpoch+1, 0), 6, alphabet)